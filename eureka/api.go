@@ -0,0 +1,227 @@
+package eureka
+
+import (
+    "bytes"
+    "crypto/tls"
+    "encoding/json"
+    "fmt"
+    "net"
+    "net/http"
+    "strings"
+)
+
+// EurekaServerApi is a thin REST client for a single Eureka server's
+// /eureka endpoints (register, heartbeat, query, deregister). Client
+// obtains one through pickEurekaServerApi/Api rather than callers
+// constructing it directly.
+type EurekaServerApi struct {
+    BaseUrl string
+
+    httpClient *http.Client
+    auth       AuthProvider
+}
+
+// NewEurekaServerApi builds an EurekaServerApi for baseUrl, configuring
+// its transport (TLS/mTLS, dial/request timeouts) and credential
+// attachment from httpConfig.
+func NewEurekaServerApi(baseUrl string, httpConfig HTTPClientConfig) *EurekaServerApi {
+    transport := &http.Transport{}
+
+    if httpConfig.TLSConfig != nil {
+        transport.TLSClientConfig = httpConfig.TLSConfig.Clone()
+    }
+    if httpConfig.InsecureSkipVerify {
+        if transport.TLSClientConfig == nil {
+            transport.TLSClientConfig = &tls.Config{}
+        }
+        transport.TLSClientConfig.InsecureSkipVerify = true
+    }
+    if httpConfig.DialTimeout > 0 {
+        transport.DialContext = (&net.Dialer{Timeout: httpConfig.DialTimeout}).DialContext
+    }
+
+    // an OAuth2 token endpoint is commonly behind the same private
+    // CA/mTLS gateway as the Eureka server itself, so share this
+    // transport with it rather than leaving it on http.DefaultClient
+    if oauth, ok := httpConfig.AuthProvider.(*OAuth2ClientCredentialsProvider); ok {
+        oauth.httpClient = &http.Client{Transport: transport, Timeout: httpConfig.RequestTimeout}
+    }
+
+    return &EurekaServerApi{
+        BaseUrl: baseUrl,
+        httpClient: &http.Client{
+            Transport: transport,
+            Timeout:   httpConfig.RequestTimeout,
+        },
+        auth: httpConfig.AuthProvider,
+    }
+}
+
+func (a *EurekaServerApi) appUrl(app string) string {
+    return strings.TrimRight(a.BaseUrl, "/") + "/apps/" + app
+}
+
+func (a *EurekaServerApi) instanceUrl(app, instanceId string) string {
+    return a.appUrl(app) + "/" + instanceId
+}
+
+func (a *EurekaServerApi) do(req *http.Request) (*http.Response, error) {
+    req.Header.Set("Accept", "application/json")
+
+    if a.auth != nil {
+        if err := a.auth.Authenticate(req); err != nil {
+            return nil, fmt.Errorf("failed to attach credentials, err=%w", err)
+        }
+    }
+
+    return a.httpClient.Do(req)
+}
+
+// RegisterInstanceWithVo registers instance and returns the instanceId it
+// was registered under, generating one from hostname/app/port if instance
+// doesn't already have one.
+func (a *EurekaServerApi) RegisterInstanceWithVo(instance *InstanceVo) (string, error) {
+    if instance.InstanceId == "" {
+        instance.InstanceId = fmt.Sprintf("%s:%s:%d", instance.HostName, instance.App, instance.Port.Value)
+    }
+
+    body, err := json.Marshal(struct {
+        Instance *InstanceVo `json:"instance"`
+    }{Instance: instance})
+    if err != nil {
+        return "", err
+    }
+
+    req, err := http.NewRequest(http.MethodPost, a.appUrl(instance.App), bytes.NewReader(body))
+    if err != nil {
+        return "", err
+    }
+    req.Header.Set("Content-Type", "application/json")
+
+    resp, err := a.do(req)
+    if err != nil {
+        return "", err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+        return "", fmt.Errorf("register %s failed, status=%d", instance.App, resp.StatusCode)
+    }
+
+    return instance.InstanceId, nil
+}
+
+// UpdateInstanceStatus sets instanceId's status.
+func (a *EurekaServerApi) UpdateInstanceStatus(app, instanceId, status string) error {
+    req, err := http.NewRequest(http.MethodPut, a.instanceUrl(app, instanceId)+"/status?value="+status, nil)
+    if err != nil {
+        return err
+    }
+
+    resp, err := a.do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("update status of %s/%s to %s failed, status=%d", app, instanceId, status, resp.StatusCode)
+    }
+    return nil
+}
+
+// SendHeartbeat renews instanceId's lease.
+func (a *EurekaServerApi) SendHeartbeat(app, instanceId string) error {
+    req, err := http.NewRequest(http.MethodPut, a.instanceUrl(app, instanceId), nil)
+    if err != nil {
+        return err
+    }
+
+    resp, err := a.do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("heartbeat for %s/%s failed, status=%d", app, instanceId, resp.StatusCode)
+    }
+    return nil
+}
+
+// DeRegisterInstance removes instanceId's registration.
+func (a *EurekaServerApi) DeRegisterInstance(app, instanceId string) error {
+    req, err := http.NewRequest(http.MethodDelete, a.instanceUrl(app, instanceId), nil)
+    if err != nil {
+        return err
+    }
+
+    resp, err := a.do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return fmt.Errorf("de-register %s/%s failed, status=%d", app, instanceId, resp.StatusCode)
+    }
+    return nil
+}
+
+// appsResponse is the wire format of a GET /apps or /apps/delta response.
+type appsResponse struct {
+    Applications struct {
+        Application  []ApplicationVo `json:"application"`
+        AppsHashcode string          `json:"apps__hashcode,omitempty"`
+    } `json:"applications"`
+}
+
+// QueryAllInstances fetches the full registry.
+func (a *EurekaServerApi) QueryAllInstances() ([]ApplicationVo, error) {
+    apps, _, err := a.queryApps("/apps")
+    return apps, err
+}
+
+// AppDelta is the decoded payload of a GET /apps/delta response: the
+// ADDED/MODIFIED/DELETED instances since the client's last fetch, plus the
+// hashcode the client's view must match after applying them.
+type AppDelta struct {
+    Applications []ApplicationVo
+    AppsHashcode string
+}
+
+// QueryAllInstancesDelta fetches the incremental registry changes queued
+// since this client's last /apps/delta fetch, along with the hashcode to
+// reconcile against.
+func (a *EurekaServerApi) QueryAllInstancesDelta() (*AppDelta, error) {
+    apps, hashcode, err := a.queryApps("/apps/delta")
+    if err != nil {
+        return nil, err
+    }
+
+    return &AppDelta{Applications: apps, AppsHashcode: hashcode}, nil
+}
+
+func (a *EurekaServerApi) queryApps(path string) ([]ApplicationVo, string, error) {
+    req, err := http.NewRequest(http.MethodGet, strings.TrimRight(a.BaseUrl, "/")+path, nil)
+    if err != nil {
+        return nil, "", err
+    }
+
+    resp, err := a.do(req)
+    if err != nil {
+        return nil, "", err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return nil, "", fmt.Errorf("%s failed, status=%d", path, resp.StatusCode)
+    }
+
+    var body appsResponse
+    if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+        return nil, "", err
+    }
+
+    return body.Applications.Application, body.Applications.AppsHashcode, nil
+}
@@ -0,0 +1,178 @@
+package eureka
+
+import (
+    "crypto/tls"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "net/url"
+    "strings"
+    "sync"
+    "time"
+)
+
+// HTTPClientConfig controls the transport an EurekaServerApi instance uses
+// to talk to a Eureka server: TLS/mTLS settings, timeouts, and how
+// credentials get attached to each request.
+type HTTPClientConfig struct {
+    // TLSConfig, when set, is used as-is by the underlying transport, e.g.
+    // to supply a CA bundle or a client certificate for mTLS.
+    TLSConfig *tls.Config
+
+    // InsecureSkipVerify disables server certificate verification. Only
+    // ever use this against a known self-signed server in local testing.
+    InsecureSkipVerify bool
+
+    // DialTimeout bounds establishing the TCP connection. Zero means the
+    // net/http default applies.
+    DialTimeout time.Duration
+
+    // RequestTimeout bounds a single request/response round trip. Zero
+    // means no timeout.
+    RequestTimeout time.Duration
+
+    // AuthProvider, when set, attaches credentials to every outgoing
+    // request instead of relying on user:pass@host credentials embedded
+    // in ServiceUrl.
+    AuthProvider AuthProvider
+}
+
+// AuthProvider attaches credentials to an outgoing EurekaServerApi
+// request.
+type AuthProvider interface {
+    Authenticate(req *http.Request) error
+}
+
+// BasicAuthProvider attaches HTTP Basic auth credentials.
+type BasicAuthProvider struct {
+    Username string
+    Password string
+}
+
+func (p *BasicAuthProvider) Authenticate(req *http.Request) error {
+    req.SetBasicAuth(p.Username, p.Password)
+    return nil
+}
+
+// BearerTokenProvider attaches a fixed bearer token. Use
+// OAuth2ClientCredentialsProvider instead if the token needs to be
+// refreshed periodically.
+type BearerTokenProvider struct {
+    Token string
+}
+
+func (p *BearerTokenProvider) Authenticate(req *http.Request) error {
+    req.Header.Set("Authorization", "Bearer "+p.Token)
+    return nil
+}
+
+// OAuth2ClientCredentialsProvider fetches a bearer token via the OAuth2
+// client-credentials grant and caches it, refreshing shortly before it
+// expires.
+type OAuth2ClientCredentialsProvider struct {
+    TokenURL     string
+    ClientID     string
+    ClientSecret string
+    Scopes       []string
+
+    // RefreshBefore controls how long before expiry a cached token is
+    // treated as stale and refetched. Defaults to 30s.
+    RefreshBefore time.Duration
+
+    // httpClient is set by NewEurekaServerApi to share the transport
+    // built from the enclosing HTTPClientConfig (TLS/mTLS, timeouts), so
+    // a token endpoint behind the same private CA/mTLS gateway as the
+    // Eureka server is reachable. Falls back to http.DefaultClient when
+    // this provider is used standalone, outside an EurekaServerApi.
+    httpClient *http.Client
+
+    mu        sync.Mutex
+    token     string
+    expiresAt time.Time
+}
+
+func (p *OAuth2ClientCredentialsProvider) Authenticate(req *http.Request) error {
+    token, err := p.tokenFor(time.Now())
+    if err != nil {
+        return err
+    }
+
+    req.Header.Set("Authorization", "Bearer "+token)
+    return nil
+}
+
+func (p *OAuth2ClientCredentialsProvider) tokenFor(now time.Time) (string, error) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    refreshBefore := p.RefreshBefore
+    if refreshBefore == 0 {
+        refreshBefore = 30 * time.Second
+    }
+
+    if p.token != "" && now.Before(p.expiresAt.Add(-refreshBefore)) {
+        return p.token, nil
+    }
+
+    httpClient := p.httpClient
+    if httpClient == nil {
+        httpClient = http.DefaultClient
+    }
+
+    token, expiresIn, err := fetchOAuth2Token(httpClient, p.TokenURL, p.ClientID, p.ClientSecret, p.Scopes)
+    if err != nil {
+        return "", err
+    }
+
+    p.token = token
+    p.expiresAt = now.Add(expiresIn)
+
+    return p.token, nil
+}
+
+func fetchOAuth2Token(httpClient *http.Client, tokenURL, clientId, clientSecret string, scopes []string) (string, time.Duration, error) {
+    form := url.Values{}
+    form.Set("grant_type", "client_credentials")
+    if len(scopes) > 0 {
+        form.Set("scope", strings.Join(scopes, " "))
+    }
+
+    req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+    if err != nil {
+        return "", 0, err
+    }
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+    req.SetBasicAuth(clientId, clientSecret)
+
+    resp, err := httpClient.Do(req)
+    if err != nil {
+        return "", 0, err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return "", 0, fmt.Errorf("oauth2 token request to %s failed, status=%d", tokenURL, resp.StatusCode)
+    }
+
+    var body struct {
+        AccessToken string `json:"access_token"`
+        ExpiresIn   int    `json:"expires_in"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+        return "", 0, err
+    }
+
+    return body.AccessToken, time.Duration(body.ExpiresIn) * time.Second, nil
+}
+
+// redactUrl strips any embedded user:pass@ credentials from url before
+// it's written to a log line.
+func redactUrl(raw string) string {
+    parsed, err := url.Parse(raw)
+    if err != nil || parsed.User == nil {
+        return raw
+    }
+
+    parsed.User = nil
+    return parsed.String()
+}
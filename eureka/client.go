@@ -1,12 +1,10 @@
 package eureka
 
 import (
+    "context"
     "errors"
-    "os"
-    "os/signal"
     "strings"
     "sync"
-    "syscall"
     "time"
 
     "go.uber.org/atomic"
@@ -34,13 +32,27 @@ type Client struct {
     // value: ApplicationVo
     registryApps map[string]ApplicationVo
 
-    // for monitor system signal
-    signalChan chan os.Signal
-
     mu sync.RWMutex
 
     //// current user server node url
     pickServerUrlIdx atomic.Int32
+
+    // whether a full /apps fetch has completed yet; until it has, delta
+    // fetches have nothing to apply on top of and a full fetch is forced
+    fullRegistryFetched atomic.Bool
+
+    // registered Subscribe/SubscribeAll channels, fanned out to on every
+    // registry refresh
+    subs subscribers
+
+    // service urls that recently failed a request and should be skipped
+    // by the picker for a while
+    quarantine quarantine
+
+    // lazily-constructed ServerSelector used when config.ServerSelector is
+    // left unset
+    defaultSelector     ServerSelector
+    defaultSelectorOnce sync.Once
 }
 
 func (t *Client) Config(config *EurekaClientConfig) *Client {
@@ -87,30 +99,57 @@ func (t *Client) GetRegistryApps() map[string]ApplicationVo {
     return t.registryApps
 }
 
-// start eureka client
-// 1. parse/get service urls
-// 2. register client to eureka server and send heartbeat
-func (t *Client) Run() {
-    err := t.refreshServiceUrls()
+// RegisterNow synchronously registers the configured instance with Eureka
+// and starts sending heartbeats for it, without the registry refresh loop
+// that Run starts. It's meant for callers that want to
+// own their own process lifecycle instead of calling Run, e.g. the go-kit
+// sd.Registrar adapter. Heartbeats stop when ctx is cancelled.
+func (t *Client) RegisterNow(ctx context.Context) error {
+    if err := t.refreshServiceUrls(ctx); err != nil {
+        return err
+    }
+
+    t.registerWithEureka(ctx)
+    go t.heartbeat(ctx)
+
+    return nil
+}
+
+// Run starts the eureka client: it resolves service urls, registers the
+// configured instance, and keeps it registered (heartbeats, registry
+// refresh) until ctx is cancelled, at which point it gracefully
+// deregisters the instance before returning. Callers that want to exit on
+// a process signal should cancel ctx themselves, e.g. via
+// InstallSignalHandler.
+//
+// Run returns nil once ctx cancellation has been handled gracefully; ctx
+// being cancelled is the expected way to stop a running client, not a
+// failure. It only returns a non-nil error when startup itself failed,
+// e.g. refreshServiceUrls couldn't resolve any service url.
+func (t *Client) Run(ctx context.Context) error {
+    err := t.refreshServiceUrls(ctx)
     if err != nil {
         log.Errorf("Failed to refresh service urls, err=%s", err.Error())
-        return
+        return err
     }
 
-    // handle exit signal to de-register instance
-    go t.handleSignal()
-
     // (if FetchRegistry is true), fetch registry apps periodically
     // and update to t.registryApps
-    go t.refreshRegistry()
+    go t.refreshRegistry(ctx)
 
-    t.registerWithAllEureka()
+    t.registerWithAllEureka(ctx)
 
     // send heartbeat
-    go t.heartbeat()
+    go t.heartbeat(ctx)
+
+    <-ctx.Done()
+
+    t.deregisterFromAllEureka()
+
+    return nil
 }
 
-func (t *Client) refreshServiceUrls() error {
+func (t *Client) refreshServiceUrls(ctx context.Context) error {
     err := t.getServiceUrlsWithZones()
     if err != nil {
         log.Errorf("Failed to init service urls, err=%s", err.Error())
@@ -124,41 +163,68 @@ func (t *Client) refreshServiceUrls() error {
             return
         }
 
-        for {
-            t.getServiceUrlsWithZones()
+        ticker := time.NewTicker(time.Duration(t.config.AutoUpdateDnsServiceUrlsIntervals) * time.Second)
+        defer ticker.Stop()
 
-            time.Sleep(time.Duration(t.config.AutoUpdateDnsServiceUrlsIntervals) * time.Second)
-            log.Debugf("AutoUpdateDnsServiceUrls... ok")
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case <-ticker.C:
+                t.getServiceUrlsWithZones()
+                log.Debugf("AutoUpdateDnsServiceUrls... ok")
+            }
         }
     }()
 
     return nil
 }
 
+// getServiceUrlsWithZones resolves service urls for every configured
+// availability zone and orders them with the client's own zone (the first
+// entry returned by GetAvailabilityZones) first, matching the reference
+// Java client's zone-affinity behavior. The own zone's urls are shuffled
+// among themselves, and the urls of all other zones are pooled and
+// shuffled together, so load isn't always driven to the same server first.
 func (t *Client) getServiceUrlsWithZones() error {
     availZones := t.config.GetAvailabilityZones(t.config.Region)
     endpointUtils := new(EndpointUtils)
 
-    // loop to get zone's service urls
-    var err error
-    var urls []string
-    for _, zone := range availZones {
-        urls, err = endpointUtils.GetDiscoveryServiceUrls(t.config, zone)
+    var ownZoneUrls []string
+    var otherZoneUrls []string
+    var lastErr error
+
+    for i, zone := range availZones {
+        urls, err := endpointUtils.GetDiscoveryServiceUrls(t.config, zone)
         if err != nil {
             log.Errorf("Failed to boot eureka client, zone=%s, err=%s", zone, err.Error())
+            lastErr = err
             continue
         }
 
-        t.mu.Lock()
-        t.serviceUrls = urls
-        t.mu.Unlock()
-        break
+        if i == 0 {
+            ownZoneUrls = append(ownZoneUrls, urls...)
+        } else {
+            otherZoneUrls = append(otherZoneUrls, urls...)
+        }
+    }
+
+    if len(ownZoneUrls) == 0 && len(otherZoneUrls) == 0 {
+        return lastErr
     }
 
-    return err
+    shuffleUrls(ownZoneUrls)
+    shuffleUrls(otherZoneUrls)
+
+    t.mu.Lock()
+    t.serviceUrls = append(ownZoneUrls, otherZoneUrls...)
+    t.mu.Unlock()
+
+    return nil
 }
 
-// rand to pick service url
+// pick service url via the configured ServerSelector (round-robin by
+// default), skipping any url currently in quarantine
 func (t *Client) pickServiceUrl() (string, bool) {
     if len(t.serviceUrls) == 0 {
         // if serviceUrls not init, try to fetch service urls one time
@@ -169,13 +235,54 @@ func (t *Client) pickServiceUrl() (string, bool) {
     }
 
     t.mu.RLock()
-    defer t.mu.RUnlock()
-    if len(t.serviceUrls) == 0 {
+    urls := t.serviceUrls
+    t.mu.RUnlock()
+    if len(urls) == 0 {
+        return "", false
+    }
+
+    ttl := time.Duration(t.config.QuarantineRefreshIntervalSeconds) * time.Second
+    candidates := make([]string, 0, len(urls))
+    for _, url := range urls {
+        if !t.quarantine.isQuarantined(url, ttl) {
+            candidates = append(candidates, url)
+        }
+    }
+    if len(candidates) == 0 {
+        // every url is quarantined, e.g. all servers are down; fall back
+        // to trying all of them rather than refusing to ever try again
+        candidates = urls
+    }
+
+    url, ok := t.serverSelector().Pick(candidates)
+    if !ok {
         return "", false
     }
 
-    shiftIdx := int(t.pickServerUrlIdx.Inc())
-    return t.serviceUrls[shiftIdx%len(t.serviceUrls)], true
+    for idx, u := range urls {
+        if u == url {
+            t.pickServerUrlIdx.Store(int32(idx))
+            break
+        }
+    }
+
+    return url, true
+}
+
+// quarantineUrl marks url as having just failed a request so the picker
+// skips it until it's flushed out by QuarantineRefreshIntervalSeconds or
+// the quarantine set reaches QuarantineThresholdRatio of all urls.
+func (t *Client) quarantineUrl(url string) {
+    if url == "" {
+        return
+    }
+
+    t.mu.RLock()
+    total := len(t.serviceUrls)
+    t.mu.RUnlock()
+
+    log.Errorf("Quarantining service url=%s", redactUrl(url))
+    t.quarantine.add(url, total, t.config.QuarantineThresholdRatio)
 }
 
 // pick current used server url
@@ -195,10 +302,16 @@ func (t *Client) currentServerUrl() (string, bool) {
 
 // rand to pick service url and new EurekaServerApi instance
 func (t *Client) pickEurekaServerApi() (*EurekaServerApi, error) {
-    // check using server url, firstly pick default url
+    // stick with the current server url, but only while it's still in
+    // good standing — a quarantined url must fall through to
+    // pickServiceUrl so the quarantine/selector logic actually gets a say
+    // on retries, instead of being pinned to the same bad url forever
     url, ok := t.currentServerUrl()
     if ok {
-        return NewEurekaServerApi(url), nil
+        ttl := time.Duration(t.config.QuarantineRefreshIntervalSeconds) * time.Second
+        if !t.quarantine.isQuarantined(url, ttl) {
+            return NewEurekaServerApi(url, t.config.HTTPClientConfig), nil
+        }
     }
 
     url, ok = t.pickServiceUrl()
@@ -207,25 +320,31 @@ func (t *Client) pickEurekaServerApi() (*EurekaServerApi, error) {
         return nil, errors.New("No service url is available to pick.")
     }
 
-    return NewEurekaServerApi(url), nil
+    return NewEurekaServerApi(url, t.config.HTTPClientConfig), nil
 }
 
-func (t *Client) registerWithAllEureka() {
+func (t *Client) registerWithAllEureka(ctx context.Context) {
     for _ = range t.serviceUrls {
-        go t.registerWithEureka()
+        go t.registerWithEureka(ctx)
         t.pickServerUrlIdx.Inc()
     }
 }
 
 // register instance (default current status is STARTING)
 // and update instance status to UP
-func (t *Client) registerWithEureka() {
+func (t *Client) registerWithEureka(ctx context.Context) {
     if !t.config.RegisterWithEureka {
         return
     }
 
     // ensure client succeed to register to eureka server
     for {
+        select {
+        case <-ctx.Done():
+            return
+        default:
+        }
+
         if t.instance == nil {
             log.Errorf("Eureka instance can't be nil")
             return
@@ -233,22 +352,28 @@ func (t *Client) registerWithEureka() {
 
         api, err := t.Api()
         if err != nil {
-            time.Sleep(time.Second * DEFAULT_SLEEP_INTERVALS)
+            sleepOrDone(ctx, time.Second*DEFAULT_SLEEP_INTERVALS)
             continue
         }
 
+        start := time.Now()
         instanceId, err := api.RegisterInstanceWithVo(t.instance)
+        t.reportLatency(api.BaseUrl, start, err)
         if err != nil {
             log.Errorf("Client register failed, err=%s", err.Error())
-            time.Sleep(time.Second * DEFAULT_SLEEP_INTERVALS)
+            t.quarantineUrl(api.BaseUrl)
+            sleepOrDone(ctx, time.Second*DEFAULT_SLEEP_INTERVALS)
             continue
         }
         t.instance.InstanceId = instanceId
 
+        start = time.Now()
         err = api.UpdateInstanceStatus(t.instance.App, t.instance.InstanceId, STATUS_UP)
+        t.reportLatency(api.BaseUrl, start, err)
         if err != nil {
             log.Errorf("Client UP failed, err=%s", err.Error())
-            time.Sleep(time.Second * DEFAULT_SLEEP_INTERVALS)
+            t.quarantineUrl(api.BaseUrl)
+            sleepOrDone(ctx, time.Second*DEFAULT_SLEEP_INTERVALS)
             continue
         }
 
@@ -259,46 +384,74 @@ func (t *Client) registerWithEureka() {
 }
 
 // eureka client heartbeat
-func (t *Client) heartbeat() {
+func (t *Client) heartbeat(ctx context.Context) {
     var latestPickIdx int32 = 0
     ticker := time.NewTicker(time.Duration(t.config.HeartbeatIntervals) * time.Second)
+    defer ticker.Stop()
 
     for {
         select {
+        case <-ctx.Done():
+            return
         case <-ticker.C:
             api, err := t.Api()
             if err != nil {
-                time.Sleep(time.Second * DEFAULT_SLEEP_INTERVALS)
+                sleepOrDone(ctx, time.Second*DEFAULT_SLEEP_INTERVALS)
                 continue
             }
 
             if latestPickIdx != t.pickServerUrlIdx.Load() {
-                t.registerWithEureka()
+                t.registerWithEureka(ctx)
                 latestPickIdx = t.pickServerUrlIdx.Load()
             }
 
+            start := time.Now()
             err = api.SendHeartbeat(t.instance.App, t.instance.InstanceId)
+            t.reportLatency(api.BaseUrl, start, err)
             if err != nil {
-                t.pickServerUrlIdx.Inc()
+                // quarantining (rather than blindly bumping the index)
+                // is what makes the next t.Api() call fall through to
+                // the quarantine/selector-aware pickServiceUrl
+                t.quarantineUrl(api.BaseUrl)
 
                 log.Errorf("Failed to send heartbeat, err=%s", err.Error())
-                time.Sleep(time.Second * DEFAULT_SLEEP_INTERVALS)
+                sleepOrDone(ctx, time.Second*DEFAULT_SLEEP_INTERVALS)
                 continue
             }
 
-            log.Debugf("Heartbeat url=%s, app=%s, instanceId=%s", api.BaseUrl, t.instance.App, t.instance.InstanceId)
+            log.Debugf("Heartbeat url=%s, app=%s, instanceId=%s", redactUrl(api.BaseUrl), t.instance.App, t.instance.InstanceId)
         }
     }
 }
 
-func (t *Client) refreshRegistry() {
+func (t *Client) refreshRegistry(ctx context.Context) {
     if !t.config.FetchRegistry {
         return
     }
 
+    ticker := time.NewTicker(time.Second * time.Duration(t.config.RegistryFetchIntervalSeconds))
+    defer ticker.Stop()
+
+    t.fetchRegistry()
+
     for {
-        t.fetchRegistry()
-        time.Sleep(time.Second * time.Duration(t.config.RegistryFetchIntervalSeconds))
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            t.fetchRegistry()
+        }
+    }
+}
+
+// sleepOrDone sleeps for d, returning early if ctx is cancelled first.
+func sleepOrDone(ctx context.Context, d time.Duration) {
+    timer := time.NewTimer(d)
+    defer timer.Stop()
+
+    select {
+    case <-timer.C:
+    case <-ctx.Done():
     }
 }
 
@@ -309,54 +462,89 @@ func (t *Client) fetchRegistry() (map[string]ApplicationVo, error) {
         return nil, err
     }
 
+    // the first fetch (and every fetch while DisableDelta is set) always
+    // goes full, since there's nothing yet to apply a delta on top of
+    if t.config.DisableDelta || !t.fullRegistryFetched.Load() {
+        return t.fetchFullRegistry(api)
+    }
+
+    return t.fetchDeltaRegistry(api)
+}
+
+func (t *Client) fetchFullRegistry(api *EurekaServerApi) (map[string]ApplicationVo, error) {
+    start := time.Now()
     apps, err := api.QueryAllInstances()
+    t.reportLatency(api.BaseUrl, start, err)
     if err != nil {
         log.Errorf("Failed to QueryAllInstances, err=%s", err.Error())
+        t.quarantineUrl(api.BaseUrl)
         return nil, err
     }
 
-    t.mu.Lock()
-    defer t.mu.Unlock()
-
     // @TODO  FilterOnlyUpInstances  true,
 
-    t.registryApps = make(map[string]ApplicationVo)
+    registryApps := make(map[string]ApplicationVo)
     for _, app := range apps {
-        t.registryApps[app.Name] = app
+        registryApps[app.Name] = app
     }
 
-    return t.registryApps, nil
+    t.mu.Lock()
+    previous := t.registryApps
+    t.registryApps = registryApps
+    t.mu.Unlock()
+
+    t.fullRegistryFetched.Store(true)
+    t.publishDiff(previous, registryApps)
+
+    return registryApps, nil
 }
 
-// for graceful kill. Here handle SIGTERM signal to do sth
-// e.g: kill -TERM $pid
-//      or "ctrl + c" to exit
-func (t *Client) handleSignal() {
-    if t.signalChan == nil {
-        t.signalChan = make(chan os.Signal)
+func (t *Client) fetchDeltaRegistry(api *EurekaServerApi) (map[string]ApplicationVo, error) {
+    start := time.Now()
+    delta, err := api.QueryAllInstancesDelta()
+    t.reportLatency(api.BaseUrl, start, err)
+    if err != nil {
+        log.Errorf("Failed to QueryAllInstancesDelta, err=%s", err.Error())
+        t.quarantineUrl(api.BaseUrl)
+        return nil, err
+    }
+
+    t.mu.RLock()
+    previous := t.registryApps
+    t.mu.RUnlock()
+
+    // applyDelta never mutates previous; it builds a fresh map, so
+    // previous stays a valid last-known-good snapshot even if the
+    // hashcode check below falls back to a full fetch
+    registryApps := applyDelta(previous, delta.Applications)
+    hashcode := registryHashcode(registryApps)
+
+    if hashcode != delta.AppsHashcode {
+        log.Debugf("Registry hashcode mismatch, want=%s got=%s, falling back to full fetch", delta.AppsHashcode, hashcode)
+        return t.fetchFullRegistry(api)
     }
 
-    signal.Notify(t.signalChan, syscall.SIGTERM, syscall.SIGINT, syscall.SIGKILL)
+    t.mu.Lock()
+    t.registryApps = registryApps
+    t.mu.Unlock()
 
-    for {
-        switch <-t.signalChan {
-        case syscall.SIGINT:
-            fallthrough
-        case syscall.SIGKILL:
-            fallthrough
-        case syscall.SIGTERM:
-            for _ = range t.serviceUrls {
-                t.DeRegisterInstance()
-                t.pickServerUrlIdx.Inc()
-            }
+    t.publishDiff(previous, registryApps)
 
-            os.Exit(0)
-        }
+    return registryApps, nil
+}
+
+// deregisterFromAllEureka deregisters the instance from every known
+// service url, not just the currently picked one, since a prior failover
+// may have registered it against more than one server.
+func (t *Client) deregisterFromAllEureka() {
+    for _ = range t.serviceUrls {
+        t.DeRegisterInstance()
+        t.pickServerUrlIdx.Inc()
     }
 }
 
 func (t *Client) DeRegisterInstance() {
-    log.Infof("Receive exit signal, client instance going to de-register, instanceId=%s.", t.instance.InstanceId)
+    log.Infof("Client instance going to de-register, instanceId=%s.", t.instance.InstanceId)
 
     // de-register instance
     api, err := t.Api()
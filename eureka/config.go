@@ -0,0 +1,81 @@
+package eureka
+
+import "strings"
+
+const (
+    DEFAULT_REGION = "default"
+    DEFAULT_ZONE   = "defaultZone"
+)
+
+// EurekaClientConfig controls how a Client resolves Eureka server urls,
+// whether/how it registers and sends heartbeats, and how it fetches and
+// refreshes the registry.
+type EurekaClientConfig struct {
+    // Region/AvailabilityZones/ServiceUrl describe how to resolve this
+    // client's own zone and the Eureka server urls to use for each zone,
+    // matching the reference Java client's eureka-client.properties.
+    Region            string
+    AvailabilityZones map[string]string // region -> comma-separated zones
+    ServiceUrl        map[string]string // zone -> comma-separated server urls
+
+    UseDnsForFetchingServiceUrls      bool
+    AutoUpdateDnsServiceUrls          bool
+    AutoUpdateDnsServiceUrlsIntervals int
+
+    RegisterWithEureka bool
+    HeartbeatIntervals int
+
+    FetchRegistry                bool
+    RegistryFetchIntervalSeconds int
+
+    // DisableDelta forces every registry refresh to be a full /apps
+    // fetch, skipping the incremental /apps/delta + hashcode
+    // reconciliation path. Useful when a server's delta cache is known to
+    // be unreliable.
+    DisableDelta bool
+
+    // QuarantineThresholdRatio and QuarantineRefreshIntervalSeconds tune
+    // how aggressively the picker avoids recently-failing service urls;
+    // see quarantine.go.
+    QuarantineThresholdRatio         float64
+    QuarantineRefreshIntervalSeconds int
+
+    // ServerSelector picks which non-quarantined service url to use next.
+    // Defaults to round-robin (see serverSelector in selector.go) when
+    // left nil; set this to inject a custom implementation, e.g.
+    // NewEwmaLatencySelector().
+    ServerSelector ServerSelector
+
+    // HTTPClientConfig controls the transport (TLS/mTLS, timeouts) and
+    // credential attachment used for every outgoing EurekaServerApi
+    // request.
+    HTTPClientConfig HTTPClientConfig
+}
+
+// GetDefaultEurekaClientConfig returns an EurekaClientConfig with the same
+// defaults as the reference Java client.
+func GetDefaultEurekaClientConfig() *EurekaClientConfig {
+    return &EurekaClientConfig{
+        Region:                            DEFAULT_REGION,
+        AvailabilityZones:                 map[string]string{DEFAULT_REGION: DEFAULT_ZONE},
+        ServiceUrl:                        map[string]string{DEFAULT_ZONE: "http://localhost:8761/eureka"},
+        RegisterWithEureka:                true,
+        HeartbeatIntervals:                30,
+        FetchRegistry:                     true,
+        RegistryFetchIntervalSeconds:      30,
+        AutoUpdateDnsServiceUrlsIntervals: 300,
+        QuarantineThresholdRatio:          0.66,
+        QuarantineRefreshIntervalSeconds:  30,
+    }
+}
+
+// GetAvailabilityZones returns the zones configured for region, with the
+// client's own zone first, falling back to DEFAULT_ZONE if none are
+// configured.
+func (c *EurekaClientConfig) GetAvailabilityZones(region string) []string {
+    zones, ok := c.AvailabilityZones[region]
+    if !ok || zones == "" {
+        return []string{DEFAULT_ZONE}
+    }
+    return strings.Split(zones, ",")
+}
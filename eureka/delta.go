@@ -0,0 +1,104 @@
+package eureka
+
+import (
+    "sort"
+    "strconv"
+    "strings"
+)
+
+const (
+    ACTION_TYPE_ADDED    = "ADDED"
+    ACTION_TYPE_MODIFIED = "MODIFIED"
+    ACTION_TYPE_DELETED  = "DELETED"
+)
+
+// applyDelta returns a new registry snapshot with the ADDED/MODIFIED/
+// DELETED instances carried by a /apps/delta response merged into current.
+// It never mutates current (or any slice reachable from it) in place, so
+// it's safe to call while other goroutines (e.g. GetRegistryApps callers)
+// hold a reference to current's maps/slices.
+func applyDelta(current map[string]ApplicationVo, apps []ApplicationVo) map[string]ApplicationVo {
+    result := make(map[string]ApplicationVo, len(current))
+    for name, app := range current {
+        instances := make([]InstanceVo, len(app.Instance))
+        copy(instances, app.Instance)
+        app.Instance = instances
+        result[name] = app
+    }
+
+    for _, deltaApp := range apps {
+        app, ok := result[deltaApp.Name]
+        if !ok {
+            app = ApplicationVo{Name: deltaApp.Name}
+        }
+
+        for _, instance := range deltaApp.Instance {
+            switch instance.ActionType {
+            case ACTION_TYPE_DELETED:
+                app.Instance = removeInstance(app.Instance, instance.InstanceId)
+            default:
+                // ADDED and MODIFIED both upsert; Eureka sends the full
+                // instance payload either way.
+                app.Instance = upsertInstance(app.Instance, instance)
+            }
+        }
+
+        if len(app.Instance) == 0 {
+            delete(result, deltaApp.Name)
+            continue
+        }
+
+        result[deltaApp.Name] = app
+    }
+
+    return result
+}
+
+func removeInstance(instances []InstanceVo, instanceId string) []InstanceVo {
+    out := instances[:0]
+    for _, instance := range instances {
+        if instance.InstanceId == instanceId {
+            continue
+        }
+        out = append(out, instance)
+    }
+    return out
+}
+
+func upsertInstance(instances []InstanceVo, instance InstanceVo) []InstanceVo {
+    for i := range instances {
+        if instances[i].InstanceId == instance.InstanceId {
+            instances[i] = instance
+            return instances
+        }
+    }
+    return append(instances, instance)
+}
+
+// registryHashcode computes the client-side hashcode Eureka servers use to
+// signal whether a client's view of the registry is still consistent, e.g.
+// "DOWN_1_UP_5_" (statuses in sorted order).
+func registryHashcode(apps map[string]ApplicationVo) string {
+    counts := make(map[string]int)
+    for _, app := range apps {
+        for _, instance := range app.Instance {
+            counts[instance.Status]++
+        }
+    }
+
+    statuses := make([]string, 0, len(counts))
+    for status := range counts {
+        statuses = append(statuses, status)
+    }
+    sort.Strings(statuses)
+
+    var b strings.Builder
+    for _, status := range statuses {
+        b.WriteString(status)
+        b.WriteByte('_')
+        b.WriteString(strconv.Itoa(counts[status]))
+        b.WriteByte('_')
+    }
+
+    return b.String()
+}
@@ -0,0 +1,109 @@
+package eureka
+
+import (
+    "reflect"
+    "sort"
+    "testing"
+)
+
+func TestApplyDelta(t *testing.T) {
+    current := map[string]ApplicationVo{
+        "APP": {
+            Name: "APP",
+            Instance: []InstanceVo{
+                {InstanceId: "i-1", Status: STATUS_UP},
+                {InstanceId: "i-2", Status: STATUS_UP},
+            },
+        },
+    }
+
+    delta := []ApplicationVo{
+        {
+            Name: "APP",
+            Instance: []InstanceVo{
+                {InstanceId: "i-2", Status: STATUS_DOWN, ActionType: ACTION_TYPE_MODIFIED},
+                {InstanceId: "i-3", Status: STATUS_UP, ActionType: ACTION_TYPE_ADDED},
+            },
+        },
+        {
+            Name: "OTHER",
+            Instance: []InstanceVo{
+                {InstanceId: "i-9", Status: STATUS_UP, ActionType: ACTION_TYPE_ADDED},
+            },
+        },
+    }
+
+    result := applyDelta(current, delta)
+
+    app := result["APP"]
+    gotIds := instanceIds(app.Instance)
+    wantIds := []string{"i-1", "i-2", "i-3"}
+    if !reflect.DeepEqual(gotIds, wantIds) {
+        t.Fatalf("APP instances = %v, want %v", gotIds, wantIds)
+    }
+
+    for _, instance := range app.Instance {
+        if instance.InstanceId == "i-2" && instance.Status != STATUS_DOWN {
+            t.Fatalf("i-2 status = %s, want %s", instance.Status, STATUS_DOWN)
+        }
+    }
+
+    if _, ok := result["OTHER"]; !ok {
+        t.Fatalf("OTHER app should have been added by the delta")
+    }
+
+    // current must be left untouched
+    if len(current["APP"].Instance) != 2 {
+        t.Fatalf("applyDelta mutated its input: current[APP] has %d instances, want 2", len(current["APP"].Instance))
+    }
+}
+
+func TestApplyDeltaDeleteDrainsApp(t *testing.T) {
+    current := map[string]ApplicationVo{
+        "APP": {
+            Name:     "APP",
+            Instance: []InstanceVo{{InstanceId: "i-1", Status: STATUS_UP}},
+        },
+    }
+
+    delta := []ApplicationVo{
+        {
+            Name:     "APP",
+            Instance: []InstanceVo{{InstanceId: "i-1", ActionType: ACTION_TYPE_DELETED}},
+        },
+    }
+
+    result := applyDelta(current, delta)
+
+    if _, ok := result["APP"]; ok {
+        t.Fatalf("APP should have been removed once its last instance was deleted")
+    }
+}
+
+func TestRegistryHashcode(t *testing.T) {
+    apps := map[string]ApplicationVo{
+        "APP": {
+            Name: "APP",
+            Instance: []InstanceVo{
+                {InstanceId: "i-1", Status: STATUS_UP},
+                {InstanceId: "i-2", Status: STATUS_UP},
+                {InstanceId: "i-3", Status: STATUS_DOWN},
+            },
+        },
+    }
+
+    got := registryHashcode(apps)
+    want := "DOWN_1_UP_2_"
+    if got != want {
+        t.Fatalf("registryHashcode = %q, want %q", got, want)
+    }
+}
+
+func instanceIds(instances []InstanceVo) []string {
+    ids := make([]string, len(instances))
+    for i, instance := range instances {
+        ids[i] = instance.InstanceId
+    }
+    sort.Strings(ids)
+    return ids
+}
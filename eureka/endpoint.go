@@ -0,0 +1,26 @@
+package eureka
+
+import (
+    "fmt"
+    "strings"
+)
+
+// EndpointUtils resolves the Eureka server urls configured for a zone.
+type EndpointUtils struct{}
+
+// GetDiscoveryServiceUrls returns the service urls configured for zone
+// under config.ServiceUrl, split on commas. DNS-based discovery
+// (config.UseDnsForFetchingServiceUrls) isn't implemented; every sample in
+// this repo configures ServiceUrl directly instead.
+func (e *EndpointUtils) GetDiscoveryServiceUrls(config *EurekaClientConfig, zone string) ([]string, error) {
+    raw, ok := config.ServiceUrl[zone]
+    if !ok || raw == "" {
+        return nil, fmt.Errorf("no service url configured for zone=%s", zone)
+    }
+
+    urls := strings.Split(raw, ",")
+    for i, url := range urls {
+        urls[i] = strings.TrimSpace(url)
+    }
+    return urls, nil
+}
@@ -0,0 +1,100 @@
+package gokit
+
+import (
+    "fmt"
+    "sync"
+
+    "github.com/go-kit/kit/log"
+    "github.com/go-kit/kit/sd"
+
+    "github.com/gopor/go-eureka-client/eureka"
+)
+
+// instancer adapts *eureka.Client's Subscribe API to sd.Instancer, pushing
+// the live, UP instance list for a single Eureka application to registered
+// listeners.
+type instancer struct {
+    client *eureka.Client
+    app    string
+    logger log.Logger
+    cancel eureka.CancelFunc
+
+    mu        sync.Mutex
+    listeners map[chan<- sd.Event]struct{}
+    instances []string
+}
+
+// NewInstancer returns a go-kit sd.Instancer tracking app's instances via
+// client's registry refresh loop.
+func NewInstancer(client *eureka.Client, app string, logger log.Logger) sd.Instancer {
+    i := &instancer{
+        client:    client,
+        app:       app,
+        logger:    logger,
+        listeners: make(map[chan<- sd.Event]struct{}),
+    }
+
+    i.instances = instanceAddrs(client.GetRegistryApps()[app])
+
+    updates, cancel := client.Subscribe(app)
+    i.cancel = cancel
+    go i.watch(updates)
+
+    return i
+}
+
+func (i *instancer) watch(updates <-chan eureka.AppUpdate) {
+    for range updates {
+        addrs := instanceAddrs(i.client.GetRegistryApps()[i.app])
+        event := sd.Event{Instances: addrs}
+
+        i.mu.Lock()
+        i.instances = addrs
+        for ch := range i.listeners {
+            select {
+            case ch <- event:
+            default:
+                i.logger.Log("msg", "listener channel full, dropping update", "app", i.app)
+            }
+        }
+        i.mu.Unlock()
+    }
+}
+
+// Register sends ch the current instance snapshot and registers it for
+// future updates. The send happens from a goroutine rather than inline:
+// go-kit's sd.NewEndpointer calls Register with an unbuffered channel and
+// only starts receiving from it after Register returns, so sending here
+// synchronously would deadlock on first use.
+func (i *instancer) Register(ch chan<- sd.Event) {
+    i.mu.Lock()
+    i.listeners[ch] = struct{}{}
+    instances := i.instances
+    i.mu.Unlock()
+
+    go func() {
+        ch <- sd.Event{Instances: instances}
+    }()
+}
+
+func (i *instancer) Deregister(ch chan<- sd.Event) {
+    i.mu.Lock()
+    defer i.mu.Unlock()
+
+    delete(i.listeners, ch)
+}
+
+func (i *instancer) Stop() {
+    i.cancel()
+}
+
+func instanceAddrs(app eureka.ApplicationVo) []string {
+    addrs := make([]string, 0, len(app.Instance))
+    for _, instance := range app.Instance {
+        if instance.Status != eureka.STATUS_UP {
+            continue
+        }
+        addrs = append(addrs, fmt.Sprintf("%s:%d", instance.HostName, instance.Port.Value))
+    }
+    return addrs
+}
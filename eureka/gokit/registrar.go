@@ -0,0 +1,62 @@
+// Package gokit adapts a *eureka.Client to the go-kit/kit/sd interfaces,
+// so it can be used as a drop-in service discovery backend for go-kit
+// clients and servers.
+package gokit
+
+import (
+    "context"
+
+    "github.com/go-kit/kit/log"
+    "github.com/go-kit/kit/sd"
+
+    "github.com/gopor/go-eureka-client/eureka"
+)
+
+// registrar adapts *eureka.Client to sd.Registrar.
+type registrar struct {
+    client   *eureka.Client
+    instance *eureka.InstanceVo
+    logger   log.Logger
+
+    ctx    context.Context
+    cancel context.CancelFunc
+}
+
+// NewRegistrar returns a go-kit sd.Registrar that registers instance with
+// Eureka through client. Register starts the instance's heartbeat loop but,
+// unlike client.Run, doesn't start the registry refresh loop, and no signal
+// handler is installed — callers own their own process lifecycle. Deregister stops
+// the heartbeat loop before deregistering.
+//
+// Register kicks registration off in the background rather than waiting
+// for it to succeed, since RegisterNow retries indefinitely until ctx is
+// cancelled and go-kit callers expect Register to return promptly even
+// while Eureka is unreachable.
+func NewRegistrar(client *eureka.Client, instance *eureka.InstanceVo, logger log.Logger) sd.Registrar {
+    ctx, cancel := context.WithCancel(context.Background())
+
+    return &registrar{
+        client:   client.RegisterVo(instance),
+        instance: instance,
+        logger:   logger,
+        ctx:      ctx,
+        cancel:   cancel,
+    }
+}
+
+func (r *registrar) Register() {
+    go func() {
+        if err := r.client.RegisterNow(r.ctx); err != nil {
+            r.logger.Log("err", err)
+            return
+        }
+
+        r.logger.Log("action", "register", "app", r.instance.App)
+    }()
+}
+
+func (r *registrar) Deregister() {
+    r.cancel()
+    r.client.DeRegisterInstance()
+    r.logger.Log("action", "deregister", "app", r.instance.App)
+}
@@ -0,0 +1,36 @@
+package eureka
+
+import (
+    stdlog "log"
+    "os"
+)
+
+// logger is the minimal logging surface the client needs. It's kept as an
+// unexported interface (rather than depending on a particular logging
+// framework) so the default can be swapped out later without changing
+// every Errorf/Debugf/Infof call site.
+type logger interface {
+    Errorf(format string, args ...interface{})
+    Debugf(format string, args ...interface{})
+    Infof(format string, args ...interface{})
+}
+
+type defaultLogger struct {
+    *stdlog.Logger
+}
+
+func (l *defaultLogger) Errorf(format string, args ...interface{}) {
+    l.Printf("[ERROR] "+format, args...)
+}
+
+func (l *defaultLogger) Debugf(format string, args ...interface{}) {
+    l.Printf("[DEBUG] "+format, args...)
+}
+
+func (l *defaultLogger) Infof(format string, args ...interface{}) {
+    l.Printf("[INFO] "+format, args...)
+}
+
+// log is the package-wide logger used throughout the client. It defaults
+// to a stdlib logger writing to stderr.
+var log logger = &defaultLogger{Logger: stdlog.New(os.Stderr, "[eureka] ", stdlog.LstdFlags)}
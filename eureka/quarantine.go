@@ -0,0 +1,59 @@
+package eureka
+
+import (
+    "math/rand"
+    "sync"
+    "time"
+)
+
+// quarantine tracks service urls that have recently failed a register,
+// heartbeat, or fetch request so the picker can skip them instead of
+// repeatedly retrying a broken server.
+type quarantine struct {
+    mu            sync.Mutex
+    quarantinedAt map[string]time.Time
+}
+
+// add quarantines url. If thresholdRatio is set and the quarantine set has
+// grown to cover that fraction of all known urls, the whole set is flushed
+// so the client doesn't end up with nowhere left to try.
+func (q *quarantine) add(url string, total int, thresholdRatio float64) {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+
+    if q.quarantinedAt == nil {
+        q.quarantinedAt = make(map[string]time.Time)
+    }
+    q.quarantinedAt[url] = time.Now()
+
+    if thresholdRatio > 0 && total > 0 && float64(len(q.quarantinedAt))/float64(total) >= thresholdRatio {
+        log.Debugf("Quarantine set reached threshold ratio=%.2f, flushing", thresholdRatio)
+        q.quarantinedAt = make(map[string]time.Time)
+    }
+}
+
+// isQuarantined reports whether url is currently quarantined, flushing it
+// out first if ttl has elapsed since it was quarantined.
+func (q *quarantine) isQuarantined(url string, ttl time.Duration) bool {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+
+    quarantinedAt, ok := q.quarantinedAt[url]
+    if !ok {
+        return false
+    }
+
+    if ttl > 0 && time.Since(quarantinedAt) > ttl {
+        delete(q.quarantinedAt, url)
+        return false
+    }
+
+    return true
+}
+
+// shuffleUrls randomizes urls in place.
+func shuffleUrls(urls []string) {
+    rand.Shuffle(len(urls), func(i, j int) {
+        urls[i], urls[j] = urls[j], urls[i]
+    })
+}
@@ -0,0 +1,58 @@
+package eureka
+
+import (
+    "testing"
+    "time"
+)
+
+func TestQuarantineIsQuarantinedAfterAdd(t *testing.T) {
+    var q quarantine
+    q.add("http://a", 3, 0)
+
+    if !q.isQuarantined("http://a", 0) {
+        t.Fatalf("url should be quarantined right after add")
+    }
+    if q.isQuarantined("http://b", 0) {
+        t.Fatalf("an unrelated url should not be quarantined")
+    }
+}
+
+func TestQuarantineFlushesAtThresholdRatio(t *testing.T) {
+    var q quarantine
+    q.add("http://a", 4, 0.5)
+    q.add("http://b", 4, 0.5)
+
+    if q.isQuarantined("http://a", 0) || q.isQuarantined("http://b", 0) {
+        t.Fatalf("quarantine set should have been flushed on reaching the threshold ratio")
+    }
+}
+
+func TestQuarantineDoesNotFlushBelowThresholdRatio(t *testing.T) {
+    var q quarantine
+    q.add("http://a", 10, 0.5)
+
+    if !q.isQuarantined("http://a", 0) {
+        t.Fatalf("url should still be quarantined while below the threshold ratio")
+    }
+}
+
+func TestQuarantineIsQuarantinedExpiresAfterTTL(t *testing.T) {
+    var q quarantine
+    q.quarantinedAt = map[string]time.Time{"http://a": time.Now().Add(-time.Minute)}
+
+    if q.isQuarantined("http://a", 10*time.Second) {
+        t.Fatalf("url should no longer be quarantined once its ttl has elapsed")
+    }
+    if _, ok := q.quarantinedAt["http://a"]; ok {
+        t.Fatalf("isQuarantined should evict expired entries")
+    }
+}
+
+func TestQuarantineIsQuarantinedZeroTTLNeverExpires(t *testing.T) {
+    var q quarantine
+    q.quarantinedAt = map[string]time.Time{"http://a": time.Now().Add(-time.Hour)}
+
+    if !q.isQuarantined("http://a", 0) {
+        t.Fatalf("a zero ttl should mean entries never expire on their own")
+    }
+}
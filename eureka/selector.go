@@ -0,0 +1,153 @@
+package eureka
+
+import (
+    "math/rand"
+    "sync"
+    "time"
+
+    "go.uber.org/atomic"
+)
+
+const (
+    ewmaAlpha            = 0.3
+    selectorEpsilonWeight = 1e-3
+)
+
+// ServerSelector picks which of the currently available (non-quarantined)
+// service urls a request should go to next, and is told the outcome of
+// every request so it can adapt. Implementations must be safe for
+// concurrent use.
+type ServerSelector interface {
+    // Pick chooses a url from candidates, which is never empty.
+    Pick(candidates []string) (string, bool)
+
+    // Report records the observed latency and error (nil on success) of a
+    // single register/heartbeat/delta/full-fetch call to url.
+    Report(url string, latency time.Duration, err error)
+}
+
+// RoundRobinSelector cycles through candidates in order. This is the
+// client's long-standing default behavior.
+type RoundRobinSelector struct {
+    idx atomic.Int32
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+    return &RoundRobinSelector{}
+}
+
+func (s *RoundRobinSelector) Pick(candidates []string) (string, bool) {
+    if len(candidates) == 0 {
+        return "", false
+    }
+
+    shiftIdx := int(s.idx.Inc())
+    return candidates[shiftIdx%len(candidates)], true
+}
+
+func (s *RoundRobinSelector) Report(url string, latency time.Duration, err error) {
+    // round robin doesn't adapt to observed outcomes
+}
+
+type urlStats struct {
+    ewmaLatencyMs float64
+    errorRate     float64
+}
+
+// EwmaLatencySelector samples urls proportional to roughly
+// 1 / (ewma_latency * (1 + error_rate)), so consistently fast, low-error
+// urls are favored. Errors at most double a url's effective latency, and
+// every weight is floored at a small epsilon rather than zero, so a
+// struggling url keeps getting occasional traffic to discover when it
+// recovers.
+type EwmaLatencySelector struct {
+    mu    sync.Mutex
+    stats map[string]*urlStats
+}
+
+func NewEwmaLatencySelector() *EwmaLatencySelector {
+    return &EwmaLatencySelector{stats: make(map[string]*urlStats)}
+}
+
+func (s *EwmaLatencySelector) Report(url string, latency time.Duration, err error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    stat, ok := s.stats[url]
+    if !ok {
+        stat = &urlStats{}
+        s.stats[url] = stat
+    }
+
+    latencyMs := float64(latency.Milliseconds())
+    if stat.ewmaLatencyMs == 0 {
+        stat.ewmaLatencyMs = latencyMs
+    } else {
+        stat.ewmaLatencyMs = ewmaAlpha*latencyMs + (1-ewmaAlpha)*stat.ewmaLatencyMs
+    }
+
+    outcome := 0.0
+    if err != nil {
+        outcome = 1.0
+    }
+    stat.errorRate = ewmaAlpha*outcome + (1-ewmaAlpha)*stat.errorRate
+}
+
+func (s *EwmaLatencySelector) weight(url string) float64 {
+    s.mu.Lock()
+    stat, ok := s.stats[url]
+    s.mu.Unlock()
+
+    if !ok || stat.ewmaLatencyMs <= 0 {
+        // no data yet, give it an average shot at being picked
+        return 1
+    }
+
+    weight := 1 / (stat.ewmaLatencyMs * (1 + stat.errorRate))
+    if weight < selectorEpsilonWeight {
+        weight = selectorEpsilonWeight
+    }
+    return weight
+}
+
+func (s *EwmaLatencySelector) Pick(candidates []string) (string, bool) {
+    if len(candidates) == 0 {
+        return "", false
+    }
+
+    weights := make([]float64, len(candidates))
+    var total float64
+    for i, url := range candidates {
+        weights[i] = s.weight(url)
+        total += weights[i]
+    }
+
+    r := rand.Float64() * total
+    for i, w := range weights {
+        r -= w
+        if r <= 0 {
+            return candidates[i], true
+        }
+    }
+
+    return candidates[len(candidates)-1], true
+}
+
+// serverSelector returns the configured ServerSelector, defaulting to
+// round-robin when the user hasn't set config.ServerSelector.
+func (t *Client) serverSelector() ServerSelector {
+    if t.config.ServerSelector != nil {
+        return t.config.ServerSelector
+    }
+
+    t.defaultSelectorOnce.Do(func() {
+        t.defaultSelector = NewRoundRobinSelector()
+    })
+    return t.defaultSelector
+}
+
+// reportLatency records the outcome of a single EurekaServerApi call with
+// the client's configured ServerSelector.
+func (t *Client) reportLatency(url string, start time.Time, err error) {
+    t.serverSelector().Report(url, time.Since(start), err)
+}
@@ -0,0 +1,90 @@
+package eureka
+
+import (
+    "testing"
+    "time"
+)
+
+func TestEwmaLatencySelectorWeightNoData(t *testing.T) {
+    s := NewEwmaLatencySelector()
+
+    if w := s.weight("http://a"); w != 1 {
+        t.Fatalf("weight with no data = %v, want 1", w)
+    }
+}
+
+func TestEwmaLatencySelectorWeightFavorsFasterLowerError(t *testing.T) {
+    s := NewEwmaLatencySelector()
+
+    s.Report("http://fast", 10*time.Millisecond, nil)
+    s.Report("http://slow", 200*time.Millisecond, nil)
+
+    if s.weight("http://fast") <= s.weight("http://slow") {
+        t.Fatalf("expected fast url to have a higher weight than slow url")
+    }
+}
+
+func TestEwmaLatencySelectorWeightStaysAboveEpsilonOnErrors(t *testing.T) {
+    s := NewEwmaLatencySelector()
+
+    for i := 0; i < 20; i++ {
+        s.Report("http://flaky", 10*time.Millisecond, errSample)
+    }
+
+    w := s.weight("http://flaky")
+    if w < selectorEpsilonWeight {
+        t.Fatalf("weight = %v, should never drop below epsilon %v", w, selectorEpsilonWeight)
+    }
+    if w > 1 {
+        t.Fatalf("weight = %v, expected a consistently failing url to be weighted low", w)
+    }
+}
+
+func TestEwmaLatencySelectorPickOnlyReturnsCandidates(t *testing.T) {
+    s := NewEwmaLatencySelector()
+    s.Report("http://a", 10*time.Millisecond, nil)
+    s.Report("http://b", 50*time.Millisecond, nil)
+
+    candidates := []string{"http://a", "http://b"}
+    for i := 0; i < 50; i++ {
+        picked, ok := s.Pick(candidates)
+        if !ok {
+            t.Fatalf("Pick returned ok=false for non-empty candidates")
+        }
+        if picked != "http://a" && picked != "http://b" {
+            t.Fatalf("Pick returned %q, not in candidates %v", picked, candidates)
+        }
+    }
+}
+
+func TestEwmaLatencySelectorPickEmptyCandidates(t *testing.T) {
+    s := NewEwmaLatencySelector()
+
+    if _, ok := s.Pick(nil); ok {
+        t.Fatalf("Pick with no candidates should return ok=false")
+    }
+}
+
+func TestRoundRobinSelectorPickCycles(t *testing.T) {
+    s := NewRoundRobinSelector()
+    candidates := []string{"http://a", "http://b", "http://c"}
+
+    seen := make(map[string]bool)
+    for i := 0; i < len(candidates); i++ {
+        picked, ok := s.Pick(candidates)
+        if !ok {
+            t.Fatalf("Pick returned ok=false for non-empty candidates")
+        }
+        seen[picked] = true
+    }
+
+    if len(seen) != len(candidates) {
+        t.Fatalf("round robin over %d picks saw %d distinct urls, want %d", len(candidates), len(seen), len(candidates))
+    }
+}
+
+var errSample = errTest("sample error")
+
+type errTest string
+
+func (e errTest) Error() string { return string(e) }
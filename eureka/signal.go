@@ -0,0 +1,27 @@
+package eureka
+
+import (
+    "context"
+    "os"
+    "os/signal"
+    "syscall"
+)
+
+// InstallSignalHandler installs a handler for SIGINT/SIGTERM that calls
+// cancel, so a context passed to Run is cancelled (and the instance
+// gracefully deregistered) when the process receives an exit signal.
+// Installing it is optional: callers that already own process signal
+// handling (test harnesses, orchestrators, multi-tenant processes) can
+// simply not call this and cancel their own context instead.
+//
+// SIGKILL is intentionally not handled here, since it can't be caught by
+// a process.
+func InstallSignalHandler(cancel context.CancelFunc) {
+    signalChan := make(chan os.Signal, 1)
+    signal.Notify(signalChan, syscall.SIGTERM, syscall.SIGINT)
+
+    go func() {
+        <-signalChan
+        cancel()
+    }()
+}
@@ -0,0 +1,188 @@
+package eureka
+
+import "sync"
+
+// subscriberChannelBuffer bounds how far a subscriber can fall behind the
+// refresh loop before its events start being dropped.
+const subscriberChannelBuffer = 32
+
+const (
+    EVENT_ADDED          = "ADDED"
+    EVENT_REMOVED        = "REMOVED"
+    EVENT_STATUS_CHANGED = "STATUS_CHANGED"
+)
+
+// AppUpdate describes instance-level changes for a single application
+// observed between two registry refreshes.
+type AppUpdate struct {
+    AppId   string
+    Added   []InstanceVo
+    Removed []InstanceVo
+    Changed []InstanceVo
+}
+
+// RegistryEvent is a single instance-level change observed anywhere in the
+// registry, as delivered by SubscribeAll.
+type RegistryEvent struct {
+    AppId    string
+    Type     string // ADDED, REMOVED, STATUS_CHANGED
+    Instance InstanceVo
+}
+
+// CancelFunc stops delivery to the channel it was returned alongside and
+// releases the subscription.
+type CancelFunc func()
+
+type subscribers struct {
+    mu    sync.Mutex
+    byApp map[string][]chan AppUpdate
+    all   []chan RegistryEvent
+}
+
+// Subscribe delivers per-instance added/removed/status-changed diffs for a
+// single application as they're observed by the registry refresh loop.
+// Events are sent non-blocking on a bounded buffer; a subscriber that falls
+// behind drops events rather than stalling the refresh loop.
+func (t *Client) Subscribe(appId string) (<-chan AppUpdate, CancelFunc) {
+    ch := make(chan AppUpdate, subscriberChannelBuffer)
+
+    t.subs.mu.Lock()
+    if t.subs.byApp == nil {
+        t.subs.byApp = make(map[string][]chan AppUpdate)
+    }
+    t.subs.byApp[appId] = append(t.subs.byApp[appId], ch)
+    t.subs.mu.Unlock()
+
+    cancel := func() {
+        t.subs.mu.Lock()
+        defer t.subs.mu.Unlock()
+        t.subs.byApp[appId] = removeAppUpdateChan(t.subs.byApp[appId], ch)
+        close(ch)
+    }
+
+    return ch, cancel
+}
+
+// SubscribeAll delivers every instance-level change observed anywhere in the
+// registry. See Subscribe for delivery semantics.
+func (t *Client) SubscribeAll() (<-chan RegistryEvent, CancelFunc) {
+    ch := make(chan RegistryEvent, subscriberChannelBuffer)
+
+    t.subs.mu.Lock()
+    t.subs.all = append(t.subs.all, ch)
+    t.subs.mu.Unlock()
+
+    cancel := func() {
+        t.subs.mu.Lock()
+        defer t.subs.mu.Unlock()
+        t.subs.all = removeRegistryEventChan(t.subs.all, ch)
+        close(ch)
+    }
+
+    return ch, cancel
+}
+
+// publishDiff compares the previous and current registry snapshots and fans
+// the resulting per-instance changes out to Subscribe/SubscribeAll callers.
+func (t *Client) publishDiff(previous, current map[string]ApplicationVo) {
+    t.subs.mu.Lock()
+    defer t.subs.mu.Unlock()
+
+    if len(t.subs.byApp) == 0 && len(t.subs.all) == 0 {
+        return
+    }
+
+    appIds := make(map[string]bool, len(previous)+len(current))
+    for appId := range previous {
+        appIds[appId] = true
+    }
+    for appId := range current {
+        appIds[appId] = true
+    }
+
+    for appId := range appIds {
+        update := diffApp(previous[appId], current[appId])
+        if len(update.Added) == 0 && len(update.Removed) == 0 && len(update.Changed) == 0 {
+            continue
+        }
+        update.AppId = appId
+
+        for _, ch := range t.subs.byApp[appId] {
+            select {
+            case ch <- update:
+            default:
+                log.Errorf("Subscribe(%s) channel is full, dropping update", appId)
+            }
+        }
+
+        t.publishEvents(appId, EVENT_ADDED, update.Added)
+        t.publishEvents(appId, EVENT_REMOVED, update.Removed)
+        t.publishEvents(appId, EVENT_STATUS_CHANGED, update.Changed)
+    }
+}
+
+// publishEvents fans the given instances out to SubscribeAll subscribers as
+// typed events. Caller must hold t.subs.mu.
+func (t *Client) publishEvents(appId, eventType string, instances []InstanceVo) {
+    for _, instance := range instances {
+        event := RegistryEvent{AppId: appId, Type: eventType, Instance: instance}
+        for _, ch := range t.subs.all {
+            select {
+            case ch <- event:
+            default:
+                log.Errorf("SubscribeAll channel is full, dropping event appId=%s type=%s", appId, eventType)
+            }
+        }
+    }
+}
+
+func diffApp(previous, current ApplicationVo) AppUpdate {
+    var update AppUpdate
+
+    prevById := make(map[string]InstanceVo, len(previous.Instance))
+    for _, instance := range previous.Instance {
+        prevById[instance.InstanceId] = instance
+    }
+
+    currIds := make(map[string]bool, len(current.Instance))
+    for _, instance := range current.Instance {
+        currIds[instance.InstanceId] = true
+
+        prevInstance, ok := prevById[instance.InstanceId]
+        if !ok {
+            update.Added = append(update.Added, instance)
+            continue
+        }
+        if prevInstance.Status != instance.Status {
+            update.Changed = append(update.Changed, instance)
+        }
+    }
+
+    for instanceId, instance := range prevById {
+        if !currIds[instanceId] {
+            update.Removed = append(update.Removed, instance)
+        }
+    }
+
+    return update
+}
+
+func removeAppUpdateChan(chans []chan AppUpdate, target chan AppUpdate) []chan AppUpdate {
+    out := chans[:0]
+    for _, ch := range chans {
+        if ch != target {
+            out = append(out, ch)
+        }
+    }
+    return out
+}
+
+func removeRegistryEventChan(chans []chan RegistryEvent, target chan RegistryEvent) []chan RegistryEvent {
+    out := chans[:0]
+    for _, ch := range chans {
+        if ch != target {
+            out = append(out, ch)
+        }
+    }
+    return out
+}
@@ -0,0 +1,58 @@
+package eureka
+
+import "os"
+
+// Instance status values, as reported by Eureka servers and used to
+// filter instances by health (e.g. gokit's instancer only surfaces
+// STATUS_UP instances).
+const (
+    STATUS_UP             = "UP"
+    STATUS_DOWN           = "DOWN"
+    STATUS_STARTING       = "STARTING"
+    STATUS_OUT_OF_SERVICE = "OUT_OF_SERVICE"
+    STATUS_UNKNOWN        = "UNKNOWN"
+)
+
+// positiveInt mirrors the Eureka wire format for fields like port that are
+// carried as {"$": <int>, "@enabled": "true"|"false"}.
+type positiveInt struct {
+    Value   int    `json:"$"`
+    Enabled string `json:"@enabled"`
+}
+
+// InstanceVo describes a single service instance as registered with, or
+// reported by, a Eureka server.
+type InstanceVo struct {
+    InstanceId       string      `json:"instanceId,omitempty"`
+    App              string      `json:"app"`
+    HostName         string      `json:"hostName,omitempty"`
+    Status           string      `json:"status"`
+    Port             positiveInt `json:"port,omitempty"`
+    VipAddress       string      `json:"vipAddress,omitempty"`
+    SecureVipAddress string      `json:"secureVipAddress,omitempty"`
+
+    // ActionType is only populated on instances carried by a
+    // /apps/delta response: ADDED, MODIFIED, or DELETED.
+    ActionType string `json:"actionType,omitempty"`
+}
+
+// DefaultInstanceVo returns an InstanceVo pre-filled with this process's
+// hostname, ready for Register to fill in the app-specific fields.
+func DefaultInstanceVo() *InstanceVo {
+    return &InstanceVo{HostName: defaultHostName()}
+}
+
+func defaultHostName() string {
+    hostname, err := os.Hostname()
+    if err != nil {
+        return "localhost"
+    }
+    return hostname
+}
+
+// ApplicationVo is a single application's instances, as returned under the
+// "applications" key of a Eureka /apps (or /apps/delta) response.
+type ApplicationVo struct {
+    Name     string       `json:"name"`
+    Instance []InstanceVo `json:"instance"`
+}
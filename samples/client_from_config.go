@@ -1,6 +1,8 @@
 package main
 
 import (
+    "context"
+
     "github.com/gopor/go-eureka-client/eureka"
 )
 
@@ -24,10 +26,14 @@ func main() {
     //    }
     //})
 
-    // run eureka client async
-    eureka.DefaultClient.Config(config).
-        Register("APP_ID_CLIENT_FROM_CONFIG", 9000).
-        Run()
+    ctx, cancel := context.WithCancel(context.Background())
+    eureka.InstallSignalHandler(cancel)
 
-    select {}
+    // run eureka client, blocking until ctx is cancelled (e.g. on SIGINT/SIGTERM)
+    err := eureka.DefaultClient.Config(config).
+        Register("APP_ID_CLIENT_FROM_CONFIG", 9000).
+        Run(ctx)
+    if err != nil {
+        panic(err)
+    }
 }